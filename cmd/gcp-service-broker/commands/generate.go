@@ -0,0 +1,78 @@
+// Copyright 2018 the Service Broker Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/GoogleCloudPlatform/gcp-service-broker/pkg/generator"
+	"github.com/spf13/cobra"
+)
+
+var generateOutput string
+
+// generateCmd is the parent of the `generate` verbs, e.g. `generate helm`.
+var generateCmd = &cobra.Command{
+	Use:   "generate",
+	Short: "Generate deployment artifacts for the broker",
+}
+
+// generateHelmCmd implements `broker generate helm`, which writes a full
+// Helm chart to --output.
+var generateHelmCmd = &cobra.Command{
+	Use:   "helm",
+	Short: "Generate a Helm chart for deploying the broker on Kubernetes",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return writeFiles(generateOutput, generator.GenerateHelmChart())
+	},
+}
+
+// generateKustomizeCmd implements `broker generate kustomize`, which writes
+// a Kustomize base layer to --output.
+var generateKustomizeCmd = &cobra.Command{
+	Use:   "kustomize",
+	Short: "Generate a Kustomize base for deploying the broker on Kubernetes",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return writeFiles(generateOutput, generator.GenerateKustomize())
+	},
+}
+
+func init() {
+	generateCmd.PersistentFlags().StringVar(&generateOutput, "output", "./chart", "directory to write generated files to")
+
+	generateCmd.AddCommand(generateHelmCmd)
+	generateCmd.AddCommand(generateKustomizeCmd)
+	rootCmd.AddCommand(generateCmd)
+}
+
+// writeFiles writes each entry of files to dir, keyed by path relative to
+// dir, creating intermediate directories as needed.
+func writeFiles(dir string, files map[string]string) error {
+	for relPath, contents := range files {
+		fullPath := filepath.Join(dir, relPath)
+
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+			return err
+		}
+
+		if err := ioutil.WriteFile(fullPath, []byte(contents), 0644); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+// Copyright 2018 the Service Broker Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/GoogleCloudPlatform/gcp-service-broker/pkg/lint"
+	"github.com/spf13/cobra"
+)
+
+var (
+	lintStrict bool
+	lintFormat string
+)
+
+// lintCmd implements `broker lint`, which walks a brokerpak directory or a
+// single service definition file and reports every schema violation it
+// finds without stopping at the first one.
+var lintCmd = &cobra.Command{
+	Use:   "lint [path]",
+	Short: "Validate brokerpak/service definitions against their JSON Schema",
+	Long: `Lint walks a directory or single YAML/JSON file containing service
+definitions, plans, and example provision/bind parameter sets, and reports
+every JSON Schema violation it finds. It exits non-zero on failure so it can
+be used as a CI gate.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		report, err := lint.Walk(args[0])
+		if err != nil {
+			return err
+		}
+
+		if err := lint.WriteReport(os.Stdout, report, lint.Format(lintFormat)); err != nil {
+			return err
+		}
+
+		if report.Failed(lintStrict) {
+			return fmt.Errorf("lint found %d finding(s)", len(report.Findings))
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	lintCmd.Flags().BoolVar(&lintStrict, "strict", false, "fail on warnings in addition to errors")
+	lintCmd.Flags().StringVar(&lintFormat, "format", string(lint.FormatText), "output format: text, json, or sarif")
+
+	rootCmd.AddCommand(lintCmd)
+}
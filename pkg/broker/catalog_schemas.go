@@ -0,0 +1,152 @@
+// Copyright 2018 the Service Broker Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package broker
+
+const (
+	// AppliesToProvision marks a BrokerVariable as settable at provision time.
+	AppliesToProvision = "provision"
+	// AppliesToUpdate marks a BrokerVariable as settable at update time.
+	AppliesToUpdate = "update"
+	// AppliesToBind marks a BrokerVariable as settable at bind time.
+	AppliesToBind = "bind"
+)
+
+// CatalogSchemas is the `schemas` block of a catalog plan entry, as defined
+// by the Open Service Broker API. Platforms such as Cloud Foundry and
+// Kubernetes Service Catalog surface these to users, e.g. via
+// `cf create-service --help`.
+// https://github.com/openservicebrokerapi/servicebroker/blob/master/spec.md#schema-object
+type CatalogSchemas struct {
+	ServiceInstance CatalogServiceInstanceSchema `json:"service_instance,omitempty"`
+	ServiceBinding  CatalogServiceBindingSchema  `json:"service_binding,omitempty"`
+}
+
+// CatalogServiceInstanceSchema holds the provision and update parameter
+// schemas for a plan.
+type CatalogServiceInstanceSchema struct {
+	Create CatalogSchema `json:"create,omitempty"`
+	Update CatalogSchema `json:"update,omitempty"`
+}
+
+// CatalogServiceBindingSchema holds the bind parameter schema for a plan.
+type CatalogServiceBindingSchema struct {
+	Create CatalogSchema `json:"create,omitempty"`
+}
+
+// CatalogSchema wraps a single JSON Schema document under the
+// `parameters` key the OSB spec expects.
+type CatalogSchema struct {
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+}
+
+// CatalogSchemas groups the service's ProvisionInputVariables and
+// BindInputVariables by AppliesTo into the three schema buckets the OSB
+// spec exposes in the catalog: service_instance.create,
+// service_instance.update, and service_binding.create. Variables that
+// don't declare AppliesTo default to applying to provision and update (for
+// ProvisionInputVariables) or bind (for BindInputVariables). Immutable
+// variables are always omitted from the update bucket.
+func (svc *ServiceDefinition) CatalogSchemas() CatalogSchemas {
+	return CatalogSchemas{
+		ServiceInstance: CatalogServiceInstanceSchema{
+			Create: CatalogSchema{Parameters: CreateJsonSchema(filterVariables(svc.ProvisionInputVariables, AppliesToProvision))},
+			Update: CatalogSchema{Parameters: CreateJsonSchema(filterVariables(svc.ProvisionInputVariables, AppliesToUpdate))},
+		},
+		ServiceBinding: CatalogServiceBindingSchema{
+			Create: CatalogSchema{Parameters: CreateJsonSchema(filterVariables(svc.BindInputVariables, AppliesToBind))},
+		},
+	}
+}
+
+// CatalogPlans returns svc.Plans with each plan's Schemas populated from
+// svc.CatalogSchemas. This is what the /v2/catalog handler should
+// marshal instead of svc.Plans directly, so that platforms like Cloud
+// Foundry and Kubernetes Service Catalog receive real per-plan parameter
+// schemas instead of an empty `schemas` block.
+func (svc *ServiceDefinition) CatalogPlans() []ServicePlan {
+	schemas := svc.CatalogSchemas()
+
+	plans := make([]ServicePlan, len(svc.Plans))
+	for i, plan := range svc.Plans {
+		plan.Schemas = schemas
+		plans[i] = plan
+	}
+
+	return plans
+}
+
+// CatalogEntry is the `services[]` entry of an Open Service Broker API
+// `/v2/catalog` response.
+// https://github.com/openservicebrokerapi/servicebroker/blob/master/spec.md#catalog-management
+type CatalogEntry struct {
+	Id             string        `json:"id"`
+	Name           string        `json:"name"`
+	Description    string        `json:"description"`
+	Bindable       bool          `json:"bindable"`
+	PlanUpdateable bool          `json:"plan_updateable,omitempty"`
+	Tags           []string      `json:"tags,omitempty"`
+	Plans          []ServicePlan `json:"plans"`
+}
+
+// ToCatalogEntry converts svc into its /v2/catalog representation, with
+// each plan's Schemas populated from svc.CatalogSchemas via CatalogPlans -
+// this is what a /v2/catalog handler should marshal instead of marshaling
+// svc or svc.Plans directly, so that platforms like Cloud Foundry and
+// Kubernetes Service Catalog receive real per-plan parameter schemas
+// instead of an empty `schemas` block.
+func (svc *ServiceDefinition) ToCatalogEntry() CatalogEntry {
+	return CatalogEntry{
+		Id:             svc.Id,
+		Name:           svc.Name,
+		Description:    svc.Description,
+		Bindable:       svc.Bindable,
+		PlanUpdateable: svc.PlanUpdateable,
+		Tags:           svc.Tags,
+		Plans:          svc.CatalogPlans(),
+	}
+}
+
+// filterVariables returns the subset of variables that apply to the given
+// bucket, applying the AppliesTo and Immutable defaulting rules described
+// on CatalogSchemas.
+func filterVariables(variables []BrokerVariable, bucket string) []BrokerVariable {
+	var out []BrokerVariable
+
+	for _, v := range variables {
+		if bucket == AppliesToUpdate && v.Immutable {
+			continue
+		}
+
+		if appliesTo(v, bucket) {
+			out = append(out, v)
+		}
+	}
+
+	return out
+}
+
+func appliesTo(v BrokerVariable, bucket string) bool {
+	if len(v.AppliesTo) == 0 {
+		return true
+	}
+
+	for _, b := range v.AppliesTo {
+		if b == bucket {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,127 @@
+// Copyright 2018 the Service Broker Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package broker
+
+import "testing"
+
+func testServiceDefinition() *ServiceDefinition {
+	return &ServiceDefinition{
+		Name: "test-service",
+		Id:   "00000000-0000-0000-0000-000000000000",
+		Plans: []ServicePlan{
+			{ID: "plan-1", Name: "default"},
+		},
+		ProvisionInputVariables: []BrokerVariable{
+			{FieldName: "project_id", Type: JsonTypeString, Details: "the GCP project id"},
+			{FieldName: "region", Type: JsonTypeString, Details: "the region", Immutable: true},
+		},
+		BindInputVariables: []BrokerVariable{
+			{FieldName: "role", Type: JsonTypeString, Details: "the IAM role to grant"},
+		},
+	}
+}
+
+func TestCatalogSchemas_DefaultsProvisionVariableToCreateAndUpdate(t *testing.T) {
+	schemas := testServiceDefinition().CatalogSchemas()
+
+	createProps := schemas.ServiceInstance.Create.Parameters["properties"].(map[string]interface{})
+	if _, ok := createProps["project_id"]; !ok {
+		t.Errorf("expected project_id in create schema, got %v", createProps)
+	}
+
+	updateProps := schemas.ServiceInstance.Update.Parameters["properties"].(map[string]interface{})
+	if _, ok := updateProps["project_id"]; !ok {
+		t.Errorf("expected project_id in update schema, got %v", updateProps)
+	}
+}
+
+func TestCatalogSchemas_OmitsImmutableVariableFromUpdate(t *testing.T) {
+	schemas := testServiceDefinition().CatalogSchemas()
+
+	updateProps := schemas.ServiceInstance.Update.Parameters["properties"].(map[string]interface{})
+	if _, ok := updateProps["region"]; ok {
+		t.Errorf("expected immutable \"region\" to be omitted from update schema, got %v", updateProps)
+	}
+
+	createProps := schemas.ServiceInstance.Create.Parameters["properties"].(map[string]interface{})
+	if _, ok := createProps["region"]; !ok {
+		t.Errorf("expected \"region\" to still be present in create schema, got %v", createProps)
+	}
+}
+
+func TestCatalogSchemas_BindVariableDefaultsToBindOnly(t *testing.T) {
+	schemas := testServiceDefinition().CatalogSchemas()
+
+	bindProps := schemas.ServiceBinding.Create.Parameters["properties"].(map[string]interface{})
+	if _, ok := bindProps["role"]; !ok {
+		t.Errorf("expected role in bind schema, got %v", bindProps)
+	}
+}
+
+func TestCatalogSchemas_ExplicitAppliesToIsRespected(t *testing.T) {
+	def := testServiceDefinition()
+	def.ProvisionInputVariables = append(def.ProvisionInputVariables, BrokerVariable{
+		FieldName: "instance_name",
+		Type:      JsonTypeString,
+		Details:   "provision-only field",
+		AppliesTo: []string{AppliesToProvision},
+	})
+
+	schemas := def.CatalogSchemas()
+
+	createProps := schemas.ServiceInstance.Create.Parameters["properties"].(map[string]interface{})
+	if _, ok := createProps["instance_name"]; !ok {
+		t.Errorf("expected instance_name in create schema, got %v", createProps)
+	}
+
+	updateProps := schemas.ServiceInstance.Update.Parameters["properties"].(map[string]interface{})
+	if _, ok := updateProps["instance_name"]; ok {
+		t.Errorf("expected instance_name restricted to provision to be omitted from update schema, got %v", updateProps)
+	}
+}
+
+func TestServiceDefinition_CatalogPlans_PopulatesSchemas(t *testing.T) {
+	def := testServiceDefinition()
+
+	plans := def.CatalogPlans()
+
+	if len(plans) != len(def.Plans) {
+		t.Fatalf("got %d plans, want %d", len(plans), len(def.Plans))
+	}
+
+	createProps := plans[0].Schemas.ServiceInstance.Create.Parameters["properties"].(map[string]interface{})
+	if _, ok := createProps["project_id"]; !ok {
+		t.Errorf("expected CatalogPlans to populate each plan's Schemas, got %v", plans[0].Schemas)
+	}
+}
+
+func TestServiceDefinition_ToCatalogEntry_UsesCatalogPlans(t *testing.T) {
+	def := testServiceDefinition()
+
+	entry := def.ToCatalogEntry()
+
+	if entry.Id != def.Id || entry.Name != def.Name {
+		t.Fatalf("expected catalog entry to carry the service's id/name, got %+v", entry)
+	}
+
+	if len(entry.Plans) != len(def.Plans) {
+		t.Fatalf("got %d plans, want %d", len(entry.Plans), len(def.Plans))
+	}
+
+	createProps := entry.Plans[0].Schemas.ServiceInstance.Create.Parameters["properties"].(map[string]interface{})
+	if _, ok := createProps["project_id"]; !ok {
+		t.Errorf("expected ToCatalogEntry's plans to carry real per-plan schemas, got %v", entry.Plans[0].Schemas)
+	}
+}
@@ -0,0 +1,147 @@
+// Copyright 2018 the Service Broker Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package broker
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/GoogleCloudPlatform/gcp-service-broker/utils"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// RegisterFormat registers a custom JSON Schema "format" keyword value
+// under name. Brokerpaks reference it from a BrokerVariable's Format field
+// (or, for hand-written schemas, `"format": name` in Constraints) and it's
+// checked the same way built-in formats like "email" are: fn receives the
+// raw value being validated, which need not be a string (the GCP formats
+// registered below accept any non-string value), and a non-nil error
+// fails validation. The failure is reported with gojsonschema's generic
+// "does not match format" message; fn's own error text is not surfaced.
+//
+// RegisterFormat panics if called twice with the same name, since that
+// almost always means two brokerpaks (or a brokerpak and the broker
+// itself) disagree about what the format means.
+func RegisterFormat(name string, fn func(interface{}) error) {
+	if _, exists := registeredFormats[name]; exists {
+		panic(fmt.Sprintf("format %q is already registered", name))
+	}
+
+	registeredFormats[name] = fn
+	gojsonschema.FormatCheckers.Add(name, formatCheckerFunc(fn))
+}
+
+var registeredFormats = map[string]func(interface{}) error{}
+
+// formatCheckerFunc adapts a func(interface{}) error to gojsonschema's
+// FormatChecker interface.
+type formatCheckerFunc func(interface{}) error
+
+// IsFormat implements gojsonschema.FormatChecker.
+func (fn formatCheckerFunc) IsFormat(input interface{}) bool {
+	return fn(input) == nil
+}
+
+func init() {
+	RegisterFormat("gcp-project-id", validateGcpProjectID)
+	RegisterFormat("gcp-region", validateGcpRegion)
+	RegisterFormat("bigquery-dataset-name", validateBigqueryDatasetName)
+	RegisterFormat("gcs-bucket-name", validateGcsBucketName)
+}
+
+// gcpProjectIDPattern matches GCP project IDs: 6-30 lowercase letters,
+// digits, and hyphens, starting with a letter and not ending with a
+// hyphen. https://cloud.google.com/resource-manager/reference/rest/v1/projects
+var gcpProjectIDPattern = regexp.MustCompile(`^[a-z][a-z0-9-]{4,28}[a-z0-9]$`)
+
+func validateGcpProjectID(input interface{}) error {
+	str, ok := input.(string)
+	if !ok {
+		return nil
+	}
+
+	if !gcpProjectIDPattern.MatchString(str) {
+		return fmt.Errorf("%q is not a valid GCP project id: must be 6-30 lowercase letters, digits, or hyphens, starting with a letter", str)
+	}
+
+	return nil
+}
+
+// gcpRegions is the set of GCP regions known at the time this format was
+// written. It intentionally doesn't try to be exhaustive or stay
+// up-to-date automatically: GCP adds regions over time, so this list
+// should be refreshed from https://cloud.google.com/compute/docs/regions-zones
+// as new regions come into common use.
+var gcpRegions = utils.NewStringSet(
+	"us-central1", "us-east1", "us-east4", "us-west1", "us-west2", "us-west3", "us-west4",
+	"northamerica-northeast1",
+	"southamerica-east1",
+	"europe-north1", "europe-west1", "europe-west2", "europe-west3", "europe-west4", "europe-west6",
+	"asia-east1", "asia-east2", "asia-northeast1", "asia-northeast2", "asia-northeast3",
+	"asia-south1", "asia-southeast1",
+	"australia-southeast1",
+)
+
+func validateGcpRegion(input interface{}) error {
+	str, ok := input.(string)
+	if !ok {
+		return nil
+	}
+
+	if !gcpRegions.Contains(str) {
+		return fmt.Errorf("%q is not a known GCP region", str)
+	}
+
+	return nil
+}
+
+// bigqueryDatasetNamePattern matches BigQuery dataset names: up to 1024
+// characters of letters, numbers, and underscores.
+// https://cloud.google.com/bigquery/docs/datasets#dataset-naming
+var bigqueryDatasetNamePattern = regexp.MustCompile(`^[A-Za-z0-9_]{1,1024}$`)
+
+func validateBigqueryDatasetName(input interface{}) error {
+	str, ok := input.(string)
+	if !ok {
+		return nil
+	}
+
+	if !bigqueryDatasetNamePattern.MatchString(str) {
+		return fmt.Errorf("%q is not a valid BigQuery dataset name: must be 1-1024 letters, numbers, or underscores", str)
+	}
+
+	return nil
+}
+
+// gcsBucketNamePattern matches the common case of GCS bucket names: 3-63
+// lowercase letters, numbers, hyphens, underscores, and dots, starting and
+// ending with a letter or number. This intentionally doesn't implement
+// every edge case in the full naming spec (e.g. the separate, looser rules
+// for "." in domain-named buckets).
+// https://cloud.google.com/storage/docs/naming-buckets
+var gcsBucketNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_.-]{1,61}[a-z0-9]$`)
+
+func validateGcsBucketName(input interface{}) error {
+	str, ok := input.(string)
+	if !ok {
+		return nil
+	}
+
+	if !gcsBucketNamePattern.MatchString(str) {
+		return fmt.Errorf("%q is not a valid GCS bucket name: must be 3-63 lowercase letters, numbers, hyphens, underscores, or dots", str)
+	}
+
+	return nil
+}
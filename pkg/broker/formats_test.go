@@ -0,0 +1,89 @@
+// Copyright 2018 the Service Broker Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package broker
+
+import "testing"
+
+func TestValidateGcpProjectID(t *testing.T) {
+	cases := map[string]bool{
+		"my-project-123": true,
+		"ab":              false,
+		"My-Project":      false,
+		"-leading-hyphen": false,
+		"trailing-hyphen-": false,
+	}
+
+	for input, wantValid := range cases {
+		err := validateGcpProjectID(input)
+		if gotValid := err == nil; gotValid != wantValid {
+			t.Errorf("validateGcpProjectID(%q) valid = %v, want %v (err: %v)", input, gotValid, wantValid, err)
+		}
+	}
+}
+
+func TestValidateGcpRegion(t *testing.T) {
+	if err := validateGcpRegion("us-central1"); err != nil {
+		t.Errorf("expected us-central1 to be a valid region, got %s", err)
+	}
+
+	if err := validateGcpRegion("mars-central1"); err == nil {
+		t.Error("expected mars-central1 to be rejected as an unknown region")
+	}
+}
+
+func TestValidateGcsBucketName(t *testing.T) {
+	if err := validateGcsBucketName("my-bucket-1"); err != nil {
+		t.Errorf("expected my-bucket-1 to be a valid bucket name, got %s", err)
+	}
+
+	if err := validateGcsBucketName("AB"); err == nil {
+		t.Error("expected \"AB\" to be rejected: too short and uppercase")
+	}
+}
+
+func TestValidateBigqueryDatasetName(t *testing.T) {
+	if err := validateBigqueryDatasetName("my_dataset_1"); err != nil {
+		t.Errorf("expected my_dataset_1 to be a valid dataset name, got %s", err)
+	}
+
+	if err := validateBigqueryDatasetName("my-dataset"); err == nil {
+		t.Error("expected \"my-dataset\" to be rejected: hyphens aren't allowed")
+	}
+}
+
+func TestRegisterFormat_PanicsOnDuplicateName(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected RegisterFormat to panic on a duplicate name")
+		}
+	}()
+
+	RegisterFormat("gcp-region", func(interface{}) error { return nil })
+}
+
+func TestBrokerVariable_ToSchema_RendersFormat(t *testing.T) {
+	bv := BrokerVariable{
+		FieldName: "region",
+		Type:      JsonTypeString,
+		Details:   "the GCP region",
+		Format:    "gcp-region",
+	}
+
+	schema := bv.ToSchema()
+
+	if got := schema["format"]; got != "gcp-region" {
+		t.Errorf("format = %v, want gcp-region", got)
+	}
+}
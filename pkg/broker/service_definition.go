@@ -0,0 +1,124 @@
+// Copyright 2018 the Service Broker Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package broker
+
+import (
+	"github.com/GoogleCloudPlatform/gcp-service-broker/pkg/validation"
+	"github.com/GoogleCloudPlatform/gcp-service-broker/utils"
+	"github.com/hashicorp/go-multierror"
+)
+
+// ServiceDefinition holds everything needed to expose a GCP service
+// through the broker: its catalog metadata, the plans it offers, the
+// variables it accepts at provision/bind time, and the example parameter
+// sets used to lint and document it.
+type ServiceDefinition struct {
+	Id             string   `yaml:"id"`
+	Name           string   `yaml:"name"`
+	Description    string   `yaml:"description"`
+	DisplayName    string   `yaml:"display_name,omitempty"`
+	Bindable       bool     `yaml:"bindable,omitempty"`
+	PlanUpdateable bool     `yaml:"plan_updateable,omitempty"`
+	Tags           []string `yaml:"tags,omitempty"`
+
+	Plans []ServicePlan `yaml:"plans,omitempty"`
+
+	ProvisionInputVariables []BrokerVariable `yaml:"provision_input_variables,omitempty"`
+	BindInputVariables      []BrokerVariable `yaml:"bind_input_variables,omitempty"`
+
+	Examples []ServiceExample `yaml:"examples,omitempty"`
+
+	// CrossFieldValidator, if set, runs after per-field JSON Schema
+	// validation succeeds and receives the fully-defaulted parameter map.
+	// It's the escape hatch for validations that span multiple fields,
+	// e.g. "instance_name length + database_name length <= 98", which
+	// can't be expressed as a single BrokerVariable's Constraints. It's a
+	// Go interface set up by the brokerpak's provider code, never by YAML.
+	CrossFieldValidator CrossFieldValidator `yaml:"-"`
+}
+
+// ServicePlan is a single plan offered for a ServiceDefinition.
+type ServicePlan struct {
+	ID          string `yaml:"id" json:"id"`
+	Name        string `yaml:"name" json:"name"`
+	Description string `yaml:"description" json:"description"`
+	// Schemas is populated by ServiceDefinition.CatalogPlans and is what
+	// gets serialized into this plan's entry in the /v2/catalog response.
+	// It's always computed, never read from a brokerpak file.
+	Schemas CatalogSchemas `yaml:"-" json:"schemas,omitempty"`
+}
+
+// ServiceExample is a sample set of provision/bind parameters used by
+// pkg/lint to validate a ServiceDefinition's schema and by documentation
+// generation to show users realistic input.
+type ServiceExample struct {
+	Name            string                 `yaml:"name"`
+	Description     string                 `yaml:"description"`
+	ProvisionParams map[string]interface{} `yaml:"provision_params,omitempty"`
+	BindParams      map[string]interface{} `yaml:"bind_params,omitempty"`
+}
+
+// CrossFieldValidator validates relationships between multiple parameters
+// that a single BrokerVariable's JSON Schema Constraints can't express.
+// Parameters is the fully-defaulted parameter map, i.e. after
+// ApplyDefaults has run.
+type CrossFieldValidator interface {
+	ValidateCrossFields(parameters map[string]interface{}) error
+}
+
+// Validate implements validation.Validatable.
+func (svc *ServiceDefinition) Validate() (errs *validation.FieldError) {
+	errs = errs.Also(
+		validation.ErrIfBlank(svc.Name, "name"),
+		validation.ErrIfBlank(svc.Id, "id"),
+	)
+
+	for i, variable := range svc.ProvisionInputVariables {
+		errs = errs.Also(variable.Validate().ViaFieldIndex("provision_input_variables", i))
+	}
+
+	for i, variable := range svc.BindInputVariables {
+		errs = errs.Also(variable.Validate().ViaFieldIndex("bind_input_variables", i))
+	}
+
+	return errs
+}
+
+// ValidateVariables validates parameters against the ServiceDefinition's
+// ProvisionInputVariables JSON Schema, then - if validation passed and a
+// CrossFieldValidator is set - runs it against the fully-defaulted
+// parameter map. Both kinds of failure surface through the same
+// multierror path as ValidateVariablesAgainstSchema.
+func (svc *ServiceDefinition) ValidateVariables(parameters map[string]interface{}) error {
+	ApplyDefaults(parameters, svc.ProvisionInputVariables)
+
+	if err := ValidateVariables(parameters, svc.ProvisionInputVariables); err != nil {
+		return err
+	}
+
+	if svc.CrossFieldValidator == nil {
+		return nil
+	}
+
+	if err := svc.CrossFieldValidator.ValidateCrossFields(parameters); err != nil {
+		allErrors := &multierror.Error{
+			ErrorFormat: utils.SingleLineErrorFormatter,
+		}
+		multierror.Append(allErrors, err)
+		return allErrors
+	}
+
+	return nil
+}
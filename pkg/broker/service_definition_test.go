@@ -0,0 +1,87 @@
+// Copyright 2018 the Service Broker Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package broker
+
+import (
+	"errors"
+	"testing"
+)
+
+type fixedCrossFieldValidator struct {
+	err error
+}
+
+func (v fixedCrossFieldValidator) ValidateCrossFields(parameters map[string]interface{}) error {
+	return v.err
+}
+
+func TestServiceDefinition_ValidateVariables_RunsCrossFieldValidatorAfterSchema(t *testing.T) {
+	def := &ServiceDefinition{
+		Name: "test-service",
+		Id:   "00000000-0000-0000-0000-000000000000",
+		ProvisionInputVariables: []BrokerVariable{
+			{FieldName: "instance_name", Type: JsonTypeString, Details: "the instance name"},
+		},
+		CrossFieldValidator: fixedCrossFieldValidator{err: errors.New("instance_name + database_name too long")},
+	}
+
+	err := def.ValidateVariables(map[string]interface{}{"instance_name": "foo"})
+	if err == nil {
+		t.Fatal("expected the CrossFieldValidator's error to surface")
+	}
+}
+
+func TestServiceDefinition_ValidateVariables_SkipsCrossFieldValidatorOnSchemaFailure(t *testing.T) {
+	called := false
+	def := &ServiceDefinition{
+		Name: "test-service",
+		Id:   "00000000-0000-0000-0000-000000000000",
+		ProvisionInputVariables: []BrokerVariable{
+			{Required: true, FieldName: "instance_name", Type: JsonTypeString, Details: "the instance name"},
+		},
+		CrossFieldValidator: crossFieldValidatorFunc(func(map[string]interface{}) error {
+			called = true
+			return nil
+		}),
+	}
+
+	if err := def.ValidateVariables(map[string]interface{}{}); err == nil {
+		t.Fatal("expected schema validation to fail on the missing required field")
+	}
+
+	if called {
+		t.Error("expected CrossFieldValidator not to run once schema validation already failed")
+	}
+}
+
+func TestServiceDefinition_ValidateVariables_NoCrossFieldValidatorIsOK(t *testing.T) {
+	def := &ServiceDefinition{
+		Name: "test-service",
+		Id:   "00000000-0000-0000-0000-000000000000",
+		ProvisionInputVariables: []BrokerVariable{
+			{FieldName: "instance_name", Type: JsonTypeString, Details: "the instance name"},
+		},
+	}
+
+	if err := def.ValidateVariables(map[string]interface{}{"instance_name": "foo"}); err != nil {
+		t.Fatalf("unexpected error with no CrossFieldValidator set: %s", err)
+	}
+}
+
+type crossFieldValidatorFunc func(parameters map[string]interface{}) error
+
+func (f crossFieldValidatorFunc) ValidateCrossFields(parameters map[string]interface{}) error {
+	return f(parameters)
+}
@@ -54,10 +54,78 @@ type BrokerVariable struct {
 	// Constraints holds JSON Schema validations defined for this variable.
 	// Keys are valid JSON Schema validation keywords, and values are their
 	// associated values.
-	// http://json-schema.org/latest/json-schema-validation.html
+	// http://json-schema.org/draft-07/schema#
 	Constraints map[string]interface{} `yaml:"constraints,omitempty"`
+	// Format is a draft-07 "format" keyword, e.g. "email" or "uri". Formats
+	// registered with RegisterFormat (see formats.go) can also be used here.
+	Format string `yaml:"format,omitempty"`
+	// Const restricts the value to a single, specific literal.
+	Const interface{} `yaml:"const,omitempty"`
+	// Examples holds sample values shown to users in generated docs; it has
+	// no effect on validation.
+	Examples []interface{} `yaml:"examples,omitempty"`
+	// OneOf, AnyOf, AllOf, and Not hold draft-07 composition schemas. Each
+	// entry is itself a JSON Schema, typically built from another
+	// BrokerVariable's Constraints.
+	OneOf []map[string]interface{} `yaml:"one_of,omitempty"`
+	AnyOf []map[string]interface{} `yaml:"any_of,omitempty"`
+	AllOf []map[string]interface{} `yaml:"all_of,omitempty"`
+	Not   map[string]interface{}   `yaml:"not,omitempty"`
+	// Conditional expresses an if/then/else relationship, e.g. "if
+	// authorized_network is set, subnetwork becomes required".
+	Conditional *Conditional `yaml:"conditional,omitempty"`
+	// AppliesTo restricts which catalog schema buckets (see
+	// CatalogSchemas) this variable is surfaced in, e.g. "provision",
+	// "update", "bind". If empty, the variable applies to every bucket its
+	// containing list is rendered into.
+	AppliesTo []string `yaml:"applies_to,omitempty"`
+	// Immutable marks a variable as settable only at provision time; it is
+	// omitted from the update schema in CatalogSchemas regardless of
+	// AppliesTo.
+	Immutable bool `yaml:"immutable,omitempty"`
 }
 
+// Conditional holds a draft-07 if/then/else triple. If is required; Then
+// and Else are each optional, but at least one of them should be set for
+// the conditional to have any effect.
+type Conditional struct {
+	If   map[string]interface{} `yaml:"if"`
+	Then map[string]interface{} `yaml:"then,omitempty"`
+	Else map[string]interface{} `yaml:"else,omitempty"`
+}
+
+// ToSchema converts a Conditional into its if/then/else schema fragment.
+func (c *Conditional) ToSchema() map[string]interface{} {
+	schema := map[string]interface{}{
+		"if": c.If,
+	}
+
+	if c.Then != nil {
+		schema["then"] = c.Then
+	}
+
+	if c.Else != nil {
+		schema["else"] = c.Else
+	}
+
+	return schema
+}
+
+// constraintKeywordAllowList holds every draft-07 validation keyword that
+// may appear in BrokerVariable.Constraints. Keywords that have first-class
+// BrokerVariable fields (oneOf, const, ...) are set directly on the
+// variable instead and are not part of this list. "format" is the one
+// exception: it is allowed in both places, and the Format field wins when
+// both are set.
+var constraintKeywordAllowList = utils.NewStringSet(
+	"multipleOf", "maximum", "exclusiveMaximum", "minimum", "exclusiveMinimum",
+	"maxLength", "minLength", "pattern", "format",
+	"items", "additionalItems", "maxItems", "minItems", "uniqueItems", "contains",
+	"maxProperties", "minProperties", "additionalProperties", "patternProperties",
+	"dependencies", "propertyNames",
+	"title", "description", "default", "readOnly", "writeOnly", "contentMediaType", "contentEncoding",
+)
+
 var _ validation.Validatable = (*ServiceDefinition)(nil)
 
 // Validate implements validation.Validatable.
@@ -66,20 +134,93 @@ func (bv *BrokerVariable) Validate() (errs *validation.FieldError) {
 		validation.ErrIfBlank(bv.FieldName, "field_name"),
 		validation.ErrIfNotJSONSchemaType(string(bv.Type), "type"),
 		validation.ErrIfBlank(bv.Details, "details"),
+		bv.validateConstraintKeywords(),
 	)
 }
 
+// validateConstraintKeywords rejects unknown or misspelled JSON Schema
+// keywords in Constraints. Keywords that have first-class BrokerVariable
+// fields, like OneOf or Const, must be set on those fields rather than
+// smuggled into Constraints; "format" is accepted in either place.
+func (bv *BrokerVariable) validateConstraintKeywords() (errs *validation.FieldError) {
+	for k := range bv.Constraints {
+		if !constraintKeywordAllowList.Contains(k) {
+			errs = errs.Also(validation.ErrInvalidValue(k, "constraints"))
+		}
+	}
+
+	return errs
+}
+
+// migrateDraft04ExclusiveBounds rewrites the draft-04 idiom for exclusive
+// bounds - a boolean `exclusiveMinimum`/`exclusiveMaximum` that modifies a
+// sibling `minimum`/`maximum` - into the draft-06+ form, where
+// `exclusiveMinimum`/`exclusiveMaximum` is itself the numeric bound. Without
+// this, a brokerpak written as `{"minimum": 5, "exclusiveMinimum": true}`
+// would silently change meaning under the new draft-07 `$schema`: draft-07
+// ignores the boolean (a non-numeric exclusiveMinimum is simply not a valid
+// keyword value and is skipped by gojsonschema) and validates against the
+// inclusive `minimum: 5` instead.
+//
+// Constraints that already use the draft-06+ numeric form, or that omit
+// these keywords entirely, pass through unchanged.
+func migrateDraft04ExclusiveBounds(constraints map[string]interface{}) map[string]interface{} {
+	if len(constraints) == 0 {
+		return constraints
+	}
+
+	migrated := make(map[string]interface{}, len(constraints))
+	for k, v := range constraints {
+		migrated[k] = v
+	}
+
+	migrateBound(migrated, "exclusiveMinimum", "minimum")
+	migrateBound(migrated, "exclusiveMaximum", "maximum")
+
+	return migrated
+}
+
+// migrateBound migrates a single draft-04 `exclusiveKeyword`/`boundKeyword`
+// pair in place.
+func migrateBound(constraints map[string]interface{}, exclusiveKeyword, boundKeyword string) {
+	exclusive, ok := constraints[exclusiveKeyword].(bool)
+	if !ok {
+		return
+	}
+
+	if !exclusive {
+		delete(constraints, exclusiveKeyword)
+		return
+	}
+
+	if bound, ok := constraints[boundKeyword]; ok {
+		constraints[exclusiveKeyword] = bound
+		delete(constraints, boundKeyword)
+	} else {
+		delete(constraints, exclusiveKeyword)
+	}
+}
+
 // ToSchema converts the BrokerVariable into the value part of a JSON Schema.
 func (bv *BrokerVariable) ToSchema() map[string]interface{} {
 	schema := map[string]interface{}{}
 
-	// Setting the auto-generated title comes first so it can be overridden
-	// manually by constraints in special cases.
+	// Setting the auto-generated title/description/default first lets them
+	// be overridden by constraints in special cases, the same way Constraints
+	// can override anything else set before the keyword loop runs below.
 	if bv.FieldName != "" {
 		schema[validation.KeyTitle] = fieldNameToLabel(bv.FieldName)
 	}
 
-	for k, v := range bv.Constraints {
+	if bv.Details != "" {
+		schema[validation.KeyDescription] = bv.Details
+	}
+
+	if bv.Default != nil {
+		schema[validation.KeyDefault] = bv.Default
+	}
+
+	for k, v := range migrateDraft04ExclusiveBounds(bv.Constraints) {
 		schema[k] = v
 	}
 
@@ -97,16 +238,42 @@ func (bv *BrokerVariable) ToSchema() map[string]interface{} {
 		schema[validation.KeyEnum] = enumeration
 	}
 
-	if bv.Details != "" {
-		schema[validation.KeyDescription] = bv.Details
-	}
-
 	if bv.Type != "" {
 		schema[validation.KeyType] = bv.Type
 	}
 
-	if bv.Default != nil {
-		schema[validation.KeyDefault] = bv.Default
+	if bv.Format != "" {
+		schema["format"] = bv.Format
+	}
+
+	if bv.Const != nil {
+		schema["const"] = bv.Const
+	}
+
+	if len(bv.Examples) > 0 {
+		schema["examples"] = bv.Examples
+	}
+
+	if len(bv.OneOf) > 0 {
+		schema["oneOf"] = bv.OneOf
+	}
+
+	if len(bv.AnyOf) > 0 {
+		schema["anyOf"] = bv.AnyOf
+	}
+
+	if len(bv.AllOf) > 0 {
+		schema["allOf"] = bv.AllOf
+	}
+
+	if bv.Not != nil {
+		schema["not"] = bv.Not
+	}
+
+	if bv.Conditional != nil {
+		for k, v := range bv.Conditional.ToSchema() {
+			schema[k] = v
+		}
 	}
 
 	return schema
@@ -153,6 +320,9 @@ func ValidateVariables(parameters map[string]interface{}, variables []BrokerVari
 }
 
 // ValidateVariables validates a list of BrokerVariables are adhering to their JSONSchema.
+// The schema's "$schema" keyword controls which JSON Schema draft gojsonschema
+// validates against, so draft-07 schemas from CreateJsonSchema (including
+// oneOf/anyOf/allOf/if-then-else) are handled without any extra wiring here.
 func ValidateVariablesAgainstSchema(parameters map[string]interface{}, schema map[string]interface{}) error {
 
 	result, err := gojsonschema.Validate(gojsonschema.NewGoLoader(schema), gojsonschema.NewGoLoader(parameters))
@@ -176,7 +346,20 @@ func ValidateVariablesAgainstSchema(parameters map[string]interface{}, schema ma
 	return allErrors
 }
 
-// CreateJsonSchema outputs a JSONSchema given a list of BrokerVariables
+// CreateJsonSchema outputs a draft-07 JSONSchema given a list of
+// BrokerVariables.
+//
+// Most draft-04 brokerpaks validate unchanged under draft-07: this
+// function never emitted the draft-04 object-level `"required": true`
+// property idiom, and draft-07 is otherwise a superset of draft-04's
+// validation keywords. The one idiom that does change meaning is a
+// boolean `exclusiveMinimum`/`exclusiveMaximum` paired with
+// `minimum`/`maximum` - draft-06 repurposed those keywords to hold the
+// bound itself. BrokerVariable.ToSchema migrates that idiom automatically
+// via migrateDraft04ExclusiveBounds, so brokerpaks don't need to rewrite
+// it by hand. Brokerpaks that want to use the new composition keywords
+// (OneOf, AnyOf, AllOf, Not, Conditional, Format, Const, Examples) simply
+// start setting those fields; no flag or opt-in is required.
 func CreateJsonSchema(schemaVariables []BrokerVariable) map[string]interface{} {
 	required := utils.NewStringSet()
 	properties := make(map[string]interface{})
@@ -189,7 +372,7 @@ func CreateJsonSchema(schemaVariables []BrokerVariable) map[string]interface{} {
 	}
 
 	schema := map[string]interface{}{
-		"$schema":    "http://json-schema.org/draft-04/schema#",
+		"$schema":    "http://json-schema.org/draft-07/schema#",
 		"type":       "object",
 		"properties": properties,
 	}
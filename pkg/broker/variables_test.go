@@ -0,0 +1,155 @@
+// Copyright 2018 the Service Broker Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package broker
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBrokerVariable_ToSchema_MigratesDraft04ExclusiveBounds(t *testing.T) {
+	bv := BrokerVariable{
+		FieldName:   "size",
+		Type:        JsonTypeInteger,
+		Details:     "size of the thing",
+		Constraints: map[string]interface{}{"minimum": 5, "exclusiveMinimum": true},
+	}
+
+	schema := bv.ToSchema()
+
+	if _, stillPresent := schema["minimum"]; stillPresent {
+		t.Errorf("expected draft-04 \"minimum\" to be migrated away, got %v", schema)
+	}
+
+	if got := schema["exclusiveMinimum"]; got != 5 {
+		t.Errorf("exclusiveMinimum = %v, want the migrated numeric bound 5", got)
+	}
+}
+
+func TestBrokerVariable_ToSchema_DropsInclusiveExclusiveMinimum(t *testing.T) {
+	bv := BrokerVariable{
+		FieldName:   "size",
+		Type:        JsonTypeInteger,
+		Details:     "size of the thing",
+		Constraints: map[string]interface{}{"minimum": 5, "exclusiveMinimum": false},
+	}
+
+	schema := bv.ToSchema()
+
+	if _, present := schema["exclusiveMinimum"]; present {
+		t.Errorf("expected exclusiveMinimum: false to be dropped, got %v", schema)
+	}
+
+	if got := schema["minimum"]; got != 5 {
+		t.Errorf("minimum = %v, want 5", got)
+	}
+}
+
+func TestBrokerVariable_ToSchema_CompositionKeywords(t *testing.T) {
+	bv := BrokerVariable{
+		FieldName: "network_config",
+		Type:      JsonTypeString,
+		Details:   "network configuration",
+		Const:     "foo",
+		Examples:  []interface{}{"foo"},
+		OneOf:     []map[string]interface{}{{"type": "string"}},
+		Conditional: &Conditional{
+			If:   map[string]interface{}{"properties": map[string]interface{}{"authorized_network": map[string]interface{}{}}},
+			Then: map[string]interface{}{"required": []interface{}{"subnetwork"}},
+		},
+	}
+
+	schema := bv.ToSchema()
+
+	if got := schema["const"]; got != "foo" {
+		t.Errorf("const = %v, want foo", got)
+	}
+
+	if _, ok := schema["oneOf"]; !ok {
+		t.Error("expected oneOf to be rendered")
+	}
+
+	if _, ok := schema["if"]; !ok {
+		t.Error("expected conditional if/then to be rendered")
+	}
+}
+
+func TestBrokerVariable_ToSchema_ConstraintsOverrideDescriptionAndDefault(t *testing.T) {
+	bv := BrokerVariable{
+		FieldName: "instance_name",
+		Type:      JsonTypeString,
+		Details:   "the instance name",
+		Default:   "default-instance",
+		Constraints: map[string]interface{}{
+			"description": "overridden description",
+			"default":     "overridden-default",
+		},
+	}
+
+	schema := bv.ToSchema()
+
+	if got := schema["description"]; got != "overridden description" {
+		t.Errorf("description = %v, want overridden description", got)
+	}
+
+	if got := schema["default"]; got != "overridden-default" {
+		t.Errorf("default = %v, want overridden-default", got)
+	}
+}
+
+func TestBrokerVariable_Validate_RejectsUnknownConstraintKeyword(t *testing.T) {
+	bv := BrokerVariable{
+		FieldName:   "size",
+		Type:        JsonTypeInteger,
+		Details:     "size of the thing",
+		Constraints: map[string]interface{}{"manimum": 1},
+	}
+
+	if errs := bv.Validate(); errs == nil {
+		t.Fatal("expected Validate() to reject the misspelled \"manimum\" keyword")
+	}
+}
+
+func TestBrokerVariable_Validate_AllowsKnownConstraintKeywords(t *testing.T) {
+	bv := BrokerVariable{
+		FieldName:   "size",
+		Type:        JsonTypeInteger,
+		Details:     "size of the thing",
+		Constraints: map[string]interface{}{"minimum": 1, "maximum": 10},
+	}
+
+	if errs := bv.Validate(); errs != nil {
+		t.Fatalf("expected Validate() to allow known constraint keywords, got %v", errs)
+	}
+}
+
+func TestCreateJsonSchema_UsesDraft07(t *testing.T) {
+	schema := CreateJsonSchema(nil)
+
+	if got, want := schema["$schema"], "http://json-schema.org/draft-07/schema#"; got != want {
+		t.Errorf("$schema = %v, want %v", got, want)
+	}
+}
+
+func TestMigrateDraft04ExclusiveBounds_NoMutationOfInput(t *testing.T) {
+	original := map[string]interface{}{"minimum": 5, "exclusiveMinimum": true}
+	originalCopy := map[string]interface{}{"minimum": 5, "exclusiveMinimum": true}
+
+	migrateDraft04ExclusiveBounds(original)
+
+	if !reflect.DeepEqual(original, originalCopy) {
+		t.Errorf("migrateDraft04ExclusiveBounds mutated its input: got %v, want %v", original, originalCopy)
+	}
+}
@@ -0,0 +1,304 @@
+// Copyright 2018 the Service Broker Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"bytes"
+	"log"
+	"text/template"
+
+	"github.com/GoogleCloudPlatform/gcp-service-broker/pkg/config/migration"
+	"github.com/GoogleCloudPlatform/gcp-service-broker/utils"
+)
+
+const (
+	chartYamlTemplate = copyrightHeader + `
+apiVersion: v2
+name: {{.appName}}
+description: '{{.appDescription}}'
+version: "{{.appVersion}}"
+appVersion: "{{.appVersion}}"
+`
+	valuesYamlTemplate = copyrightHeader + `
+replicaCount: 1
+
+image:
+  repository: gcr.io/{{.appName}}/{{.appName}}
+  tag: "{{.appVersion}}"
+  pullPolicy: IfNotPresent
+
+service:
+  type: ClusterIP
+  port: 80
+
+ingress:
+  enabled: false
+
+gcpServiceAccountJsonKey: ""
+`
+	helmDeploymentYamlTemplate = copyrightHeader + `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: {{.appName}}
+  labels:
+    app.kubernetes.io/name: {{.appName}}
+spec:
+  replicas: {{ "{{ .Values.replicaCount }}" }}
+  selector:
+    matchLabels:
+      app.kubernetes.io/name: {{.appName}}
+  template:
+    metadata:
+      labels:
+        app.kubernetes.io/name: {{.appName}}
+    spec:
+      serviceAccountName: {{.appName}}
+      initContainers:
+      - name: migrate
+        image: '{{ "{{ .Values.image.repository }}:{{ .Values.image.tag }}" }}'
+        command: ["/bin/sh", "-c"]
+        args:
+        - |
+{{.migrationScript}}
+        envFrom:
+        - configMapRef:
+            name: {{.appName}}-config
+        - secretRef:
+            name: {{.appName}}-gcp-credentials
+      containers:
+      - name: {{.appName}}
+        image: '{{ "{{ .Values.image.repository }}:{{ .Values.image.tag }}" }}'
+        ports:
+        - containerPort: 8080
+        envFrom:
+        - configMapRef:
+            name: {{.appName}}-config
+        - secretRef:
+            name: {{.appName}}-gcp-credentials
+`
+	helmServiceYamlTemplate = copyrightHeader + `
+apiVersion: v1
+kind: Service
+metadata:
+  name: {{.appName}}
+  labels:
+    app.kubernetes.io/name: {{.appName}}
+spec:
+  type: '{{ "{{ .Values.service.type }}" }}'
+  ports:
+  - port: {{ "{{ .Values.service.port }}" }}
+    targetPort: 8080
+  selector:
+    app.kubernetes.io/name: {{.appName}}
+`
+	helmServiceAccountYamlTemplate = copyrightHeader + `
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: {{.appName}}
+`
+	helmSecretYamlTemplate = copyrightHeader + `
+apiVersion: v1
+kind: Secret
+metadata:
+  name: {{.appName}}-gcp-credentials
+type: Opaque
+stringData:
+  GOOGLE_CREDENTIALS: '{{ "{{ .Values.gcpServiceAccountJsonKey }}" }}'
+`
+	helmConfigMapYamlTemplate = copyrightHeader + `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: {{.appName}}-config
+data:
+  GOPACKAGENAME: {{.goPackageName}}
+  GOVERSION: {{.goVersion}}
+`
+	helmIngressYamlTemplate = copyrightHeader + `
+{{ "{{- if .Values.ingress.enabled }}" }}
+apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata:
+  name: {{.appName}}
+spec:
+  rules:
+  - http:
+      paths:
+      - path: /
+        pathType: Prefix
+        backend:
+          service:
+            name: {{.appName}}
+            port:
+              number: {{ "{{ .Values.service.port }}" }}
+{{ "{{- end }}" }}
+`
+	kustomizationYamlTemplate = copyrightHeader + `
+resources:
+- deployment.yaml
+- service.yaml
+- serviceaccount.yaml
+- configmap.yaml
+- secret.yaml
+`
+)
+
+// GenerateHelmChart renders a full Helm chart for deploying the broker on
+// Kubernetes with the Service Catalog or Crossplane. The returned map is
+// keyed by path relative to the chart's root directory, e.g.
+// "Chart.yaml" or "templates/deployment.yaml".
+func GenerateHelmChart() map[string]string {
+	return renderHelmChart(migration.FullMigration().TileScript)
+}
+
+func renderHelmChart(migrationScript string) map[string]string {
+	vars := k8sTemplateVars(migrationScript)
+
+	return map[string]string{
+		"Chart.yaml":                    runK8sTemplate(chartYamlTemplate, vars),
+		"values.yaml":                   runK8sTemplate(valuesYamlTemplate, vars),
+		"templates/deployment.yaml":     runK8sTemplate(helmDeploymentYamlTemplate, vars),
+		"templates/service.yaml":        runK8sTemplate(helmServiceYamlTemplate, vars),
+		"templates/serviceaccount.yaml": runK8sTemplate(helmServiceAccountYamlTemplate, vars),
+		"templates/secret.yaml":         runK8sTemplate(helmSecretYamlTemplate, vars),
+		"templates/configmap.yaml":      runK8sTemplate(helmConfigMapYamlTemplate, vars),
+		"templates/ingress.yaml":        runK8sTemplate(helmIngressYamlTemplate, vars),
+	}
+}
+
+// GenerateKustomize renders a base Kustomize layer for the broker, reusing
+// the same plain (non-Helm-templated) resources a cluster admin could
+// overlay with their own patches. The returned map is keyed by path
+// relative to the kustomize base directory.
+func GenerateKustomize() map[string]string {
+	return renderKustomize(migration.FullMigration().TileScript)
+}
+
+func renderKustomize(migrationScript string) map[string]string {
+	vars := k8sTemplateVars(migrationScript)
+
+	return map[string]string{
+		"kustomization.yaml":  runK8sTemplate(kustomizationYamlTemplate, vars),
+		"deployment.yaml":     runK8sTemplate(plainDeploymentYamlTemplate, vars),
+		"service.yaml":        runK8sTemplate(plainServiceYamlTemplate, vars),
+		"serviceaccount.yaml": runK8sTemplate(helmServiceAccountYamlTemplate, vars),
+		"configmap.yaml":      runK8sTemplate(helmConfigMapYamlTemplate, vars),
+		"secret.yaml":         runK8sTemplate(plainSecretYamlTemplate, vars),
+	}
+}
+
+const (
+	plainDeploymentYamlTemplate = copyrightHeader + `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: {{.appName}}
+  labels:
+    app.kubernetes.io/name: {{.appName}}
+spec:
+  replicas: 1
+  selector:
+    matchLabels:
+      app.kubernetes.io/name: {{.appName}}
+  template:
+    metadata:
+      labels:
+        app.kubernetes.io/name: {{.appName}}
+    spec:
+      serviceAccountName: {{.appName}}
+      initContainers:
+      - name: migrate
+        image: 'gcr.io/{{.appName}}/{{.appName}}:{{.appVersion}}'
+        command: ["/bin/sh", "-c"]
+        args:
+        - |
+{{.migrationScript}}
+        envFrom:
+        - configMapRef:
+            name: {{.appName}}-config
+        - secretRef:
+            name: {{.appName}}-gcp-credentials
+      containers:
+      - name: {{.appName}}
+        image: 'gcr.io/{{.appName}}/{{.appName}}:{{.appVersion}}'
+        ports:
+        - containerPort: 8080
+        envFrom:
+        - configMapRef:
+            name: {{.appName}}-config
+        - secretRef:
+            name: {{.appName}}-gcp-credentials
+`
+	plainSecretYamlTemplate = copyrightHeader + `
+# GOOGLE_CREDENTIALS is intentionally blank here: Kustomize has no
+# equivalent of Helm's values.yaml, so supply the real GCP service account
+# key via a kustomize secretGenerator, a patch, or a SealedSecret overlay
+# rather than editing this file in place.
+apiVersion: v1
+kind: Secret
+metadata:
+  name: {{.appName}}-gcp-credentials
+type: Opaque
+stringData:
+  GOOGLE_CREDENTIALS: ""
+`
+	plainServiceYamlTemplate = copyrightHeader + `
+apiVersion: v1
+kind: Service
+metadata:
+  name: {{.appName}}
+  labels:
+    app.kubernetes.io/name: {{.appName}}
+spec:
+  type: ClusterIP
+  ports:
+  - port: 80
+    targetPort: 8080
+  selector:
+    app.kubernetes.io/name: {{.appName}}
+`
+)
+
+// k8sTemplateVars builds the template variables shared by the Helm chart
+// and Kustomize base. migrationScript is rendered as the body of the
+// migrate initContainer's `args: - |` block scalar, so it's indented two
+// spaces deeper than that list item rather than matching args:'s own
+// indentation.
+func k8sTemplateVars(migrationScript string) map[string]interface{} {
+	return map[string]interface{}{
+		"appName":         appName,
+		"appVersion":      utils.Version,
+		"appDescription":  appDescription,
+		"goPackageName":   goPackageName,
+		"goVersion":       goVersion,
+		"migrationScript": utils.Indent(migrationScript, "          "),
+	}
+}
+
+func runK8sTemplate(templateString string, vars map[string]interface{}) string {
+	tmpl, err := template.New("tmpl").Parse(templateString)
+	if err != nil {
+		log.Fatalf("parsing: %s", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, vars); err != nil {
+		log.Fatalf("execution: %s", err)
+	}
+
+	return buf.String()
+}
@@ -16,10 +16,12 @@ package generator
 
 import (
 	"bytes"
+	"fmt"
 	"log"
 	"text/template"
 
 	"github.com/GoogleCloudPlatform/gcp-service-broker/pkg/config/migration"
+	"github.com/GoogleCloudPlatform/gcp-service-broker/pkg/lint"
 	"github.com/GoogleCloudPlatform/gcp-service-broker/utils"
 )
 
@@ -99,14 +101,44 @@ packages:
 `
 )
 
-// GenerateManifest creates a manifest.yml from a template.
-func GenerateManifest() string {
-	return runPcfTemplate(manifestYmlTemplate)
+// GenerateManifest creates a manifest.yml from a template, after linting
+// the brokerpak service definitions in brokerpakDir.
+func GenerateManifest(brokerpakDir string) (string, error) {
+	if err := lintBrokerpak(brokerpakDir); err != nil {
+		return "", err
+	}
+
+	return runPcfTemplate(manifestYmlTemplate), nil
+}
+
+// GenerateTile creates a tile.yml from a template, after linting the
+// brokerpak service definitions in brokerpakDir.
+func GenerateTile(brokerpakDir string) (string, error) {
+	if err := lintBrokerpak(brokerpakDir); err != nil {
+		return "", err
+	}
+
+	return runPcfTemplate(tileYmlTemplate) + GenerateFormsString(), nil
 }
 
-// GenerateTile creates a tile.yml from a template.
-func GenerateTile() string {
-	return runPcfTemplate(tileYmlTemplate) + GenerateFormsString()
+// lintBrokerpak runs pkg/lint over brokerpakDir and fails strictly, so that
+// a typo'd constraint keyword or malformed example doesn't get baked into a
+// rendered deployment artifact.
+func lintBrokerpak(brokerpakDir string) error {
+	report, err := lint.Walk(brokerpakDir)
+	if err != nil {
+		return fmt.Errorf("linting brokerpak: %w", err)
+	}
+
+	if report.Failed(true) {
+		var findings bytes.Buffer
+		if err := lint.WriteReport(&findings, report, lint.FormatText); err != nil {
+			return fmt.Errorf("brokerpak failed linting: %w", err)
+		}
+		return fmt.Errorf("brokerpak failed linting, findings:\n%s", findings.String())
+	}
+
+	return nil
 }
 
 func runPcfTemplate(templateString string) string {
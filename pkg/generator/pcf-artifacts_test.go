@@ -0,0 +1,73 @@
+// Copyright 2018 the Service Broker Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeBrokerpakFixture(t *testing.T, contents string) string {
+	t.Helper()
+
+	dir, err := ioutil.TempDir("", "pcf-artifacts-test")
+	if err != nil {
+		t.Fatalf("could not create temp dir: %s", err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	if err := ioutil.WriteFile(filepath.Join(dir, "service.yml"), []byte(contents), 0644); err != nil {
+		t.Fatalf("could not write fixture: %s", err)
+	}
+
+	return dir
+}
+
+func TestGenerateManifest_FailsOnBrokerpakLintErrors(t *testing.T) {
+	dir := writeBrokerpakFixture(t, `
+id: 00000000-0000-0000-0000-000000000000
+name: test-service
+description: a test service
+provision_input_variables:
+- field_name: size
+  type: integer
+  details: size of the thing
+  constraints:
+    manimum: 1
+`)
+
+	if _, err := GenerateManifest(dir); err == nil {
+		t.Fatal("expected GenerateManifest to fail when the brokerpak has lint errors")
+	}
+}
+
+func TestGenerateManifest_SucceedsOnCleanBrokerpak(t *testing.T) {
+	dir := writeBrokerpakFixture(t, `
+id: 00000000-0000-0000-0000-000000000000
+name: test-service
+description: a test service
+`)
+
+	manifest, err := GenerateManifest(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if manifest == "" {
+		t.Fatal("expected a non-empty manifest")
+	}
+}
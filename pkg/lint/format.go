@@ -0,0 +1,156 @@
+// Copyright 2018 the Service Broker Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Format is an output format the Report can be rendered as.
+type Format string
+
+const (
+	// FormatText renders the report as human readable lines, one finding
+	// per line.
+	FormatText Format = "text"
+	// FormatJSON renders the report as a JSON array of findings.
+	FormatJSON Format = "json"
+	// FormatSARIF renders the report as a minimal SARIF 2.1.0 document so it
+	// can be consumed by code-scanning tooling.
+	FormatSARIF Format = "sarif"
+)
+
+// WriteReport renders report to w using the given format.
+func WriteReport(w io.Writer, report *Report, format Format) error {
+	switch format {
+	case FormatText, "":
+		return writeText(w, report)
+	case FormatJSON:
+		return writeJSON(w, report)
+	case FormatSARIF:
+		return writeSARIF(w, report)
+	default:
+		return fmt.Errorf("unknown lint format %q, want one of text, json, sarif", format)
+	}
+}
+
+func writeText(w io.Writer, report *Report) error {
+	for _, f := range report.Findings {
+		keyword := f.Keyword
+		if keyword == "" {
+			keyword = "-"
+		}
+
+		if _, err := fmt.Fprintf(w, "%s: %s: [%s] %s (%s)\n", f.Severity, f.File, keyword, f.Message, f.Path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeJSON(w io.Writer, report *Report) error {
+	findings := report.Findings
+	if findings == nil {
+		findings = []Finding{}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(findings)
+}
+
+// sarifLog, sarifRun, sarifResult, and sarifLocation are a deliberately
+// minimal subset of the SARIF 2.1.0 object model - just enough for a
+// result list that CI code-scanning consumers can ingest.
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name string `json:"name"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+func writeSARIF(w io.Writer, report *Report) error {
+	log := sarifLog{
+		Version: "2.1.0",
+		Schema:  "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json",
+		Runs: []sarifRun{
+			{
+				Tool: sarifTool{Driver: sarifDriver{Name: "broker-lint"}},
+			},
+		},
+	}
+
+	for _, f := range report.Findings {
+		ruleID := f.Keyword
+		if ruleID == "" {
+			ruleID = "broker-lint"
+		}
+
+		level := "warning"
+		if f.Severity == SeverityError {
+			level = "error"
+		}
+
+		log.Runs[0].Results = append(log.Runs[0].Results, sarifResult{
+			RuleID:  ruleID,
+			Level:   level,
+			Message: sarifMessage{Text: f.Message},
+			Locations: []sarifLocation{
+				{PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: f.File}}},
+			},
+		})
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(log)
+}
@@ -0,0 +1,95 @@
+// Copyright 2018 the Service Broker Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package lint validates brokerpak/service definitions against the JSON
+// Schema that would be generated for them at runtime. It is shared by the
+// `broker lint` CLI verb and by the generator, which can lint its inputs
+// before rendering a manifest or tile.
+package lint
+
+// Severity indicates how serious a Finding is.
+type Severity string
+
+const (
+	// SeverityError is used for findings that make a definition invalid.
+	SeverityError Severity = "error"
+	// SeverityWarning is used for findings that are discouraged but not
+	// strictly invalid, e.g. a service definition or example with no
+	// description. A BrokerVariable with no `details` is an error, not a
+	// warning, because BrokerVariable.Validate rejects it. Warnings only
+	// fail a strict lint run: `broker lint --strict`, or the generator's
+	// lint of its inputs before rendering.
+	SeverityWarning Severity = "warning"
+)
+
+// Finding is a single schema violation or warning discovered while linting
+// a file.
+type Finding struct {
+	// File is the path of the file the finding was discovered in: the root
+	// that was linted, joined with the file's path beneath it when the
+	// root is a directory.
+	File string
+	// Path is the JSON pointer-ish path to the offending value, e.g.
+	// "examples[0](basic).provision" or "plans(default).id".
+	Path string
+	// Keyword is the JSON Schema validation keyword that was violated, e.g.
+	// "required" or "pattern".
+	Keyword string
+	// Message is a human readable description of the violation.
+	Message string
+	// Severity indicates whether the finding is an error or a warning.
+	Severity Severity
+}
+
+// Report is the result of linting a directory or file.
+type Report struct {
+	Findings []Finding
+}
+
+// AddError appends an error-severity finding to the report.
+func (r *Report) AddError(file, path, keyword, message string) {
+	r.Findings = append(r.Findings, Finding{File: file, Path: path, Keyword: keyword, Message: message, Severity: SeverityError})
+}
+
+// AddWarning appends a warning-severity finding to the report.
+func (r *Report) AddWarning(file, path, keyword, message string) {
+	r.Findings = append(r.Findings, Finding{File: file, Path: path, Keyword: keyword, Message: message, Severity: SeverityWarning})
+}
+
+// HasErrors returns true if the report contains any error-severity findings.
+func (r *Report) HasErrors() bool {
+	for _, f := range r.Findings {
+		if f.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// HasWarnings returns true if the report contains any warning-severity
+// findings.
+func (r *Report) HasWarnings() bool {
+	for _, f := range r.Findings {
+		if f.Severity == SeverityWarning {
+			return true
+		}
+	}
+	return false
+}
+
+// Failed returns true if the report should cause a non-zero CLI exit code.
+// Warnings only count as failures when strict is true.
+func (r *Report) Failed(strict bool) bool {
+	return r.HasErrors() || (strict && r.HasWarnings())
+}
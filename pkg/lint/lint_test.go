@@ -0,0 +1,98 @@
+// Copyright 2018 the Service Broker Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lint
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/gcp-service-broker/pkg/broker"
+)
+
+func TestLintServiceDefinition_RejectsUnknownConstraintKeyword(t *testing.T) {
+	def := &broker.ServiceDefinition{
+		Name:        "test-service",
+		Id:          "00000000-0000-0000-0000-000000000000",
+		Description: "a test service",
+		ProvisionInputVariables: []broker.BrokerVariable{
+			{
+				FieldName: "size",
+				Type:      broker.JsonTypeInteger,
+				Details:   "size of the thing",
+				// "manimum" is a typo of "minimum" and must be rejected by
+				// BrokerVariable.Validate()'s constraint keyword allow-list.
+				Constraints: map[string]interface{}{"manimum": 1},
+			},
+		},
+	}
+
+	report := &Report{}
+	LintServiceDefinition("test.yml", def, report)
+
+	if !report.HasErrors() {
+		t.Fatalf("expected a finding for the unknown constraint keyword, got none: %+v", report.Findings)
+	}
+}
+
+func TestLintServiceDefinition_ValidatesExamplesAgainstSchema(t *testing.T) {
+	def := &broker.ServiceDefinition{
+		Name:        "test-service",
+		Id:          "00000000-0000-0000-0000-000000000000",
+		Description: "a test service",
+		ProvisionInputVariables: []broker.BrokerVariable{
+			{
+				Required:  true,
+				FieldName: "project_id",
+				Type:      broker.JsonTypeString,
+				Details:   "the GCP project id",
+			},
+		},
+		Examples: []broker.ServiceExample{
+			{
+				Name:            "missing required field",
+				Description:     "omits project_id",
+				ProvisionParams: map[string]interface{}{},
+			},
+		},
+	}
+
+	report := &Report{}
+	LintServiceDefinition("test.yml", def, report)
+
+	if !report.HasErrors() {
+		t.Fatalf("expected a finding for the example missing a required field, got none: %+v", report.Findings)
+	}
+}
+
+func TestWriteReport_UnknownFormat(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteReport(&buf, &Report{}, Format("bogus")); err == nil {
+		t.Fatal("expected an error for an unknown format, got nil")
+	}
+}
+
+func TestWriteReport_Text(t *testing.T) {
+	report := &Report{}
+	report.AddError("test.yml", "plans[0].id", "required", "plan is missing an id")
+
+	var buf bytes.Buffer
+	if err := WriteReport(&buf, report, FormatText); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if buf.Len() == 0 {
+		t.Fatal("expected text output, got none")
+	}
+}
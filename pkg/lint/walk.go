@@ -0,0 +1,144 @@
+// Copyright 2018 the Service Broker Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lint
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/gcp-service-broker/pkg/broker"
+	"gopkg.in/yaml.v2"
+)
+
+// serviceDefinitionFile is the on-disk shape of a single brokerpak service
+// definition file: a ServiceDefinition plus the example provision/bind
+// parameter sets used to exercise it.
+type serviceDefinitionFile struct {
+	broker.ServiceDefinition `yaml:",inline"`
+}
+
+// Walk walks root, which may be a single YAML/JSON file or a directory
+// tree, and lints every service definition file it finds. It never stops
+// at the first error: every violation in every file is collected onto the
+// returned Report.
+func Walk(root string) (*Report, error) {
+	report := &Report{}
+
+	info, err := os.Stat(root)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", root, err)
+	}
+
+	if !info.IsDir() {
+		lintFile(root, report)
+		return report, nil
+	}
+
+	err = filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		if !isServiceDefinitionFile(path) {
+			return nil
+		}
+
+		lintFile(path, report)
+		return nil
+	})
+
+	return report, err
+}
+
+func isServiceDefinitionFile(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yml", ".yaml", ".json":
+		return true
+	default:
+		return false
+	}
+}
+
+// lintFile loads a single service definition file and appends any findings
+// to report. Load failures are themselves reported as findings rather than
+// returned, so a single malformed file doesn't stop the walk.
+func lintFile(path string, report *Report) {
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		report.AddError(path, "", "", fmt.Sprintf("could not read file: %s", err))
+		return
+	}
+
+	var def serviceDefinitionFile
+	if err := yaml.UnmarshalStrict(raw, &def); err != nil {
+		report.AddError(path, "", "", fmt.Sprintf("could not parse service definition: %s", err))
+		return
+	}
+
+	LintServiceDefinition(path, &def.ServiceDefinition, report)
+}
+
+// LintServiceDefinition validates a single ServiceDefinition's examples
+// against the JSON Schema derived from its BrokerVariables, appending any
+// violations to report. file is recorded on findings for display purposes
+// only.
+func LintServiceDefinition(file string, def *broker.ServiceDefinition, report *Report) {
+	if def.Name == "" {
+		report.AddWarning(file, "name", "required", "service definition is missing a name")
+	}
+
+	if def.Description == "" {
+		report.AddWarning(file, "description", "required", "service definition is missing a description")
+	}
+
+	// def.Validate() recurses into every BrokerVariable's Validate(), which
+	// is what rejects unknown/misspelled JSON Schema keywords in
+	// Constraints - without this, CreateJsonSchema would copy a typo'd
+	// keyword straight into the rendered schema and gojsonschema would
+	// silently ignore it.
+	if errs := def.Validate(); errs != nil {
+		report.AddError(file, "", "", errs.Error())
+	}
+
+	provisionSchema := broker.CreateJsonSchema(def.ProvisionInputVariables)
+	bindSchema := broker.CreateJsonSchema(def.BindInputVariables)
+
+	for i, example := range def.Examples {
+		prefix := fmt.Sprintf("examples[%d](%s)", i, example.Name)
+
+		if example.Description == "" {
+			report.AddWarning(file, prefix+".description", "required", "example is missing a description")
+		}
+
+		if err := broker.ValidateVariablesAgainstSchema(example.ProvisionParams, provisionSchema); err != nil {
+			report.AddError(file, prefix+".provision", "", err.Error())
+		}
+
+		if err := broker.ValidateVariablesAgainstSchema(example.BindParams, bindSchema); err != nil {
+			report.AddError(file, prefix+".bind", "", err.Error())
+		}
+	}
+
+	for _, plan := range def.Plans {
+		if plan.ID == "" {
+			report.AddError(file, fmt.Sprintf("plans(%s).id", plan.Name), "required", "plan is missing an id")
+		}
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright 2018 the Service Broker Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lint
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const validServiceDefinitionYaml = `
+id: 00000000-0000-0000-0000-000000000000
+name: test-service
+description: a test service
+display_name: Test Service
+bindable: true
+plan_updateable: true
+tags:
+- gcp
+- test
+plans:
+- id: 00000000-0000-0000-0000-000000000001
+  name: default
+  description: the default plan
+provision_input_variables:
+- field_name: project_id
+  type: string
+  details: the GCP project id
+  required: true
+bind_input_variables:
+- field_name: role
+  type: string
+  details: the IAM role to grant
+examples:
+- name: basic
+  description: a basic example
+  provision_params:
+    project_id: my-project-123
+  bind_params:
+    role: roles/editor
+`
+
+// writeFixture writes contents to name inside a fresh temp directory and
+// returns the directory.
+func writeFixture(t *testing.T, name, contents string) string {
+	t.Helper()
+
+	dir, err := ioutil.TempDir("", "lint-walk-test")
+	if err != nil {
+		t.Fatalf("could not create temp dir: %s", err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(contents), 0644); err != nil {
+		t.Fatalf("could not write fixture: %s", err)
+	}
+
+	return dir
+}
+
+func TestWalk_ParsesIdiomaticSnakeCaseFields(t *testing.T) {
+	dir := writeFixture(t, "service.yml", validServiceDefinitionYaml)
+
+	report, err := Walk(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if report.HasErrors() {
+		t.Fatalf("expected no findings for a valid service definition, got: %+v", report.Findings)
+	}
+}
+
+func TestWalk_SingleFile(t *testing.T) {
+	dir := writeFixture(t, "service.yml", validServiceDefinitionYaml)
+
+	report, err := Walk(filepath.Join(dir, "service.yml"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if report.HasErrors() {
+		t.Fatalf("expected no findings for a valid service definition, got: %+v", report.Findings)
+	}
+}
+
+func TestWalk_ReportsUnknownFieldAsStrictUnmarshalError(t *testing.T) {
+	// "nam" is a typo of "name". yaml.UnmarshalStrict should reject it as an
+	// unknown field rather than silently dropping it.
+	const badYaml = `
+id: 00000000-0000-0000-0000-000000000000
+nam: test-service
+description: a test service
+`
+
+	dir := writeFixture(t, "service.yml", badYaml)
+
+	report, err := Walk(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if !report.HasErrors() {
+		t.Fatalf("expected a finding for the unknown field, got none: %+v", report.Findings)
+	}
+}
+
+func TestWalk_SkipsNonServiceDefinitionFiles(t *testing.T) {
+	dir := writeFixture(t, "README.md", "not a service definition")
+
+	report, err := Walk(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(report.Findings) != 0 {
+		t.Fatalf("expected README.md to be skipped, got findings: %+v", report.Findings)
+	}
+}